@@ -0,0 +1,36 @@
+// Package archivefs adapts archive/tar and archive/zip readers to
+// fs.FS, so a Matcher chain built for a real filesystem can run against
+// the contents of an archive without extracting it first.
+package archivefs
+
+import (
+	"io"
+	"sync"
+)
+
+// Decompressor wraps a raw archive stream (e.g. gzip- or zstd-compressed
+// tar data) with a reader that produces the decompressed bytes.
+type Decompressor func(io.Reader) (io.Reader, error)
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = map[string]Decompressor{
+		".gz": newGzipDecompressor,
+	}
+)
+
+// RegisterDecompressor registers a Decompressor for archive names ending
+// in ext (e.g. ".zst", ".bz2"), so NewFromTar can transparently unwrap
+// container formats like .tar.zst. ext must include the leading dot.
+func RegisterDecompressor(ext string, d Decompressor) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+	decompressors[ext] = d
+}
+
+func decompressorFor(ext string) (Decompressor, bool) {
+	decompressorsMu.RLock()
+	defer decompressorsMu.RUnlock()
+	d, ok := decompressors[ext]
+	return d, ok
+}
@@ -0,0 +1,127 @@
+package archivefs
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func buildTar(t *testing.T, files map[string]int64) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, mode := range files {
+		data := []byte("data:" + name)
+		if err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Size: int64(len(data)),
+			Mode: mode,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewFromTarImplicitParents(t *testing.T) {
+	raw := buildTar(t, map[string]int64{"a/b/c/file.txt": 0644})
+	tfs, err := NewFromTar("", bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range []string{".", "a", "a/b", "a/b/c"} {
+		info, err := fs.Stat(tfs, dir)
+		if err != nil {
+			t.Fatalf("stat %q: %v", dir, err)
+		}
+		if !info.IsDir() {
+			t.Errorf("expected %q to be a synthetic directory", dir)
+		}
+	}
+
+	entries, err := fs.ReadDir(tfs, "a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "c" {
+		t.Errorf("ReadDir(a/b) = %v, want [c]", entries)
+	}
+
+	data, err := fs.ReadFile(tfs, "a/b/c/file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "data:a/b/c/file.txt" {
+		t.Errorf("ReadFile = %q", data)
+	}
+}
+
+func TestNewFromTarExecutableBit(t *testing.T) {
+	raw := buildTar(t, map[string]int64{
+		"run.sh":   0755,
+		"data.txt": 0644,
+	})
+	tfs, err := NewFromTar("", bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := fs.Stat(tfs, "run.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Mode()&0111 == 0 {
+		t.Errorf("expected run.sh to keep its executable bit, got mode %v", run.Mode())
+	}
+
+	data, err := fs.Stat(tfs, "data.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Mode()&0111 != 0 {
+		t.Errorf("expected data.txt to not be executable, got mode %v", data.Mode())
+	}
+}
+
+func TestNewFromTarInvalidPath(t *testing.T) {
+	tfs, err := NewFromTar("", bytes.NewReader(buildTar(t, map[string]int64{"f.txt": 0644})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tfs.Open("../f.txt"); err == nil {
+		t.Error("expected Open to reject a path escaping the archive root")
+	}
+	if _, err := tfs.Open("missing.txt"); !errIsNotExist(err) {
+		t.Errorf("expected ErrNotExist for a missing entry, got %v", err)
+	}
+}
+
+func errIsNotExist(err error) bool {
+	pe, ok := err.(*fs.PathError)
+	return ok && pe.Err == fs.ErrNotExist
+}
+
+func TestNewFromTarDirRead(t *testing.T) {
+	raw := buildTar(t, map[string]int64{"x/y.txt": 0644})
+	tfs, err := NewFromTar("", bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := tfs.Open("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.(io.Reader).Read(make([]byte, 1)); err == nil {
+		t.Error("expected Read on a directory entry to fail")
+	}
+}
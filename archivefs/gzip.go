@@ -0,0 +1,10 @@
+package archivefs
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+func newGzipDecompressor(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
@@ -0,0 +1,167 @@
+package archivefs
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewFromTar reads a tar stream into memory and returns it as an fs.FS.
+// name is used only to pick a Decompressor (via its extension, e.g.
+// ".gz" for a ".tar.gz" archive); pass "" for an already-raw tar stream.
+// Executable bits come straight from the stored tar.Header mode, so
+// FiletypeMatcher's FT_EXECUTABLE check works unmodified against entries
+// that were never extracted to a real filesystem.
+func NewFromTar(name string, r io.Reader) (fs.FS, error) {
+	if d, ok := decompressorFor(path.Ext(name)); ok {
+		dr, err := d(r)
+		if err != nil {
+			return nil, err
+		}
+		r = dr
+	}
+
+	t := &tarFS{entries: map[string]*tarEntry{
+		".": {info: syntheticDirInfo(".")},
+	}}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := cleanArchivePath(hdr.Name)
+		if name == "." {
+			continue
+		}
+		t.ensureParents(path.Dir(name))
+
+		var data []byte
+		if hdr.Typeflag != tar.TypeDir {
+			data, err = io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		info := hdr.FileInfo()
+		if info.IsDir() {
+			t.entries[name] = &tarEntry{info: info}
+		} else {
+			t.entries[name] = &tarEntry{info: info, data: data}
+		}
+	}
+	t.linkChildren()
+	return t, nil
+}
+
+func cleanArchivePath(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	name = path.Clean(name)
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+type tarEntry struct {
+	info     fs.FileInfo
+	data     []byte
+	children []fs.DirEntry
+}
+
+type tarFS struct {
+	entries map[string]*tarEntry
+}
+
+func (t *tarFS) ensureParents(dir string) {
+	if dir == "." || dir == "" {
+		return
+	}
+	if _, ok := t.entries[dir]; ok {
+		return
+	}
+	t.ensureParents(path.Dir(dir))
+	t.entries[dir] = &tarEntry{info: syntheticDirInfo(path.Base(dir))}
+}
+
+func (t *tarFS) linkChildren() {
+	names := make([]string, 0, len(t.entries))
+	for name := range t.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "." {
+			continue
+		}
+		parent := t.entries[path.Dir(name)]
+		parent.children = append(parent.children, fs.FileInfoToDirEntry(t.entries[name].info))
+	}
+}
+
+func (t *tarFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := t.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if e.info.IsDir() {
+		return &tarFile{info: e.info, children: e.children}, nil
+	}
+	return &tarFile{info: e.info, r: bytes.NewReader(e.data)}, nil
+}
+
+type tarFile struct {
+	info       fs.FileInfo
+	r          *bytes.Reader
+	children   []fs.DirEntry
+	readDirPos int
+}
+
+func (f *tarFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *tarFile) Read(p []byte) (int, error) {
+	if f.r == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.info.Name(), Err: errors.New("is a directory")}
+	}
+	return f.r.Read(p)
+}
+
+func (f *tarFile) Close() error { return nil }
+
+func (f *tarFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := f.children[f.readDirPos:]
+	if n <= 0 {
+		f.readDirPos = len(f.children)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	f.readDirPos += n
+	return rest[:n], nil
+}
+
+type syntheticDirInfo string
+
+func (d syntheticDirInfo) Name() string       { return string(d) }
+func (d syntheticDirInfo) Size() int64        { return 0 }
+func (d syntheticDirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0755 }
+func (d syntheticDirInfo) ModTime() time.Time { return time.Time{} }
+func (d syntheticDirInfo) IsDir() bool        { return true }
+func (d syntheticDirInfo) Sys() any           { return nil }
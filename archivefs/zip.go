@@ -0,0 +1,15 @@
+package archivefs
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+)
+
+// NewFromZip adapts a zip archive to an fs.FS. *zip.Reader already
+// implements fs.FS and reports executable bits from the entry's stored
+// Unix mode when the archive carries them, so no extra work is needed
+// beyond exposing the constructor under this package.
+func NewFromZip(r io.ReaderAt, size int64) (fs.FS, error) {
+	return zip.NewReader(r, size)
+}
@@ -0,0 +1,266 @@
+package finddd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ExecOption configures an ExecMatcher or ExecBatchMatcher.
+type ExecOption func(*execConfig)
+
+// WithExecJobs bounds how many exec invocations run at once, independent
+// of the walker's own worker count. Defaults to 1.
+func WithExecJobs(n int) ExecOption {
+	return func(c *execConfig) {
+		if n > 0 {
+			c.jobs = n
+		}
+	}
+}
+
+// execConfig dispatches commands onto its own semaphore-bounded
+// goroutine pool instead of running them on the caller's goroutine, so a
+// slow -exec can't stall the matcher pipeline that feeds it.
+type execConfig struct {
+	jobs    int
+	semOnce sync.Once
+	sem     chan struct{}
+	wg      sync.WaitGroup
+
+	mu          sync.Mutex
+	lastErr     error
+	worstCode   int
+	sawAbnormal bool
+}
+
+func (c *execConfig) sema() chan struct{} {
+	c.semOnce.Do(func() {
+		jobs := c.jobs
+		if jobs < 1 {
+			jobs = 1
+		}
+		c.sem = make(chan struct{}, jobs)
+	})
+	return c.sem
+}
+
+func (c *execConfig) dispatch(fn func() error) {
+	sem := c.sema()
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		sem <- struct{}{}
+		defer func() { <-sem }()
+		c.record(fn())
+	}()
+}
+
+func (c *execConfig) record(err error) {
+	code := exitCodeOf(err)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.lastErr = err
+	}
+	// A -1 (failed to start or signal-killed) always wins over a prior
+	// success; it isn't an exit code that "greater" ordering applies to.
+	if code == -1 {
+		c.sawAbnormal = true
+	}
+	if !c.sawAbnormal && code > c.worstCode {
+		c.worstCode = code
+	}
+}
+
+// exitCodeOf maps a command's error to its exit code, or -1 if it was
+// signal-killed or never started at all.
+func exitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code >= 0 {
+			return code
+		}
+	}
+	return -1
+}
+
+// Wait blocks until every dispatched command has finished running.
+func (c *execConfig) Wait() { c.wg.Wait() }
+
+// ExitCode returns the worst exit code observed across every command
+// dispatched so far, or -1 if any of them failed to start or was killed
+// by a signal.
+func (c *execConfig) ExitCode() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sawAbnormal {
+		return -1
+	}
+	return c.worstCode
+}
+
+// Err returns the most recent non-nil error observed from a dispatched
+// command, if any.
+func (c *execConfig) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr
+}
+
+// WithExec builds an ExecMatcher that runs argv once per match.
+func WithExec(argv []string, opts ...ExecOption) *ExecMatcher {
+	em := &ExecMatcher{argv: argv}
+	for _, opt := range opts {
+		opt(&em.execConfig)
+	}
+	return em
+}
+
+// ExecMatcher runs argv for every path it sees, with fd-style
+// placeholder substitution. Match dispatches asynchronously and reports a
+// match for every path it dispatches (it reports false only when argv is
+// empty); use Wait and ExitCode to learn how the dispatched commands
+// actually fared.
+type ExecMatcher struct {
+	execConfig
+	argv []string
+}
+
+func (m *ExecMatcher) Match(fsys fs.FS, path string) bool {
+	argv := substitutePlaceholders(m.argv, path)
+	if len(argv) == 0 {
+		return false
+	}
+	m.dispatch(func() error {
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	})
+	return true
+}
+
+// WithExecBatch builds an ExecBatchMatcher that runs argv once per batch
+// of batchSize matches, the same way `find -exec cmd {} +` batches.
+func WithExecBatch(argv []string, batchSize int, opts ...ExecOption) *ExecBatchMatcher {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	ebm := &ExecBatchMatcher{argv: argv, batchSize: batchSize}
+	for _, opt := range opts {
+		opt(&ebm.execConfig)
+	}
+	return ebm
+}
+
+// ExecBatchMatcher accumulates matches and runs argv once per batch of
+// batchSize, appending the batch's paths as trailing arguments (or in
+// place of a literal "{}" argument, if present). Call Close once the
+// walk is done to flush any partial final batch.
+type ExecBatchMatcher struct {
+	execConfig
+	argv      []string
+	batchSize int
+
+	mu      sync.Mutex
+	pending []string
+}
+
+func (m *ExecBatchMatcher) Match(fsys fs.FS, path string) bool {
+	m.mu.Lock()
+	m.pending = append(m.pending, path)
+	var batch []string
+	if len(m.pending) >= m.batchSize {
+		batch = m.pending
+		m.pending = nil
+	}
+	m.mu.Unlock()
+
+	if batch != nil {
+		m.runBatch(batch)
+	}
+	return true
+}
+
+// Close flushes any remaining paths that didn't fill a full batch, then
+// waits for every dispatched batch (including this final one).
+func (m *ExecBatchMatcher) Close() error {
+	m.mu.Lock()
+	batch := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+	if len(batch) > 0 {
+		m.runBatch(batch)
+	}
+	m.Wait()
+	return nil
+}
+
+func (m *ExecBatchMatcher) runBatch(paths []string) {
+	argv := make([]string, 0, len(m.argv)+len(paths))
+	hasPlaceholder := false
+	for _, a := range m.argv {
+		if a == "{}" {
+			argv = append(argv, paths...)
+			hasPlaceholder = true
+		} else {
+			argv = append(argv, a)
+		}
+	}
+	if !hasPlaceholder {
+		argv = append(argv, paths...)
+	}
+	if len(argv) == 0 {
+		return
+	}
+
+	m.dispatch(func() error {
+		cmd := exec.Command(argv[0], argv[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	})
+}
+
+// substitutePlaceholders expands the fd-style {}, {/}, {//}, {.} and
+// {/.} placeholders in argv for a single matched path.
+func substitutePlaceholders(argv []string, path string) []string {
+	base := filepath.Base(path)
+	parent := filepath.Dir(path)
+	ext := extOf(path)
+	noExt := strings.TrimSuffix(path, ext)
+	baseNoExt := strings.TrimSuffix(base, ext)
+
+	replacer := strings.NewReplacer(
+		"{//}", parent,
+		"{/.}", baseNoExt,
+		"{/}", base,
+		"{.}", noExt,
+		"{}", path,
+	)
+
+	out := make([]string, len(argv))
+	for i, a := range argv {
+		out[i] = replacer.Replace(a)
+	}
+	return out
+}
+
+// extOf is like filepath.Ext, except a filename that starts with "." and
+// has no further "." (e.g. ".bashrc") has no extension.
+func extOf(path string) string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	if ext == base {
+		return ""
+	}
+	return ext
+}
@@ -0,0 +1,108 @@
+package finddd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSubstitutePlaceholders(t *testing.T) {
+	cases := map[string]struct {
+		tmpl string
+		path string
+		want string
+	}{
+		"path":                   {"{}", "sub/dir/file.txt", "sub/dir/file.txt"},
+		"basename":               {"{/}", "sub/dir/file.txt", "file.txt"},
+		"parent":                 {"{//}", "sub/dir/file.txt", "sub/dir"},
+		"no-ext":                 {"{.}", "sub/dir/file.txt", "sub/dir/file"},
+		"basename-noext":         {"{/.}", "sub/dir/file.txt", "file"},
+		"dotfile-noext":          {"{.}", "sub/.bashrc", "sub/.bashrc"},
+		"dotfile-basename-noext": {"{/.}", "sub/.bashrc", ".bashrc"},
+		"dotfile-with-ext":       {"{.}", "sub/.bashrc.bak", "sub/.bashrc"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := substitutePlaceholders([]string{c.tmpl}, c.path)
+			if len(got) != 1 || got[0] != c.want {
+				t.Errorf("substitutePlaceholders(%q, %q) = %v, want %q", c.tmpl, c.path, got, c.want)
+			}
+		})
+	}
+}
+
+func TestExecMatcherDispatchesAndAggregatesExitCode(t *testing.T) {
+	em := WithExec([]string{"sh", "-c", "exit 0"}, WithExecJobs(2))
+	if !em.Match(nil, "a") {
+		t.Error("expected Match to report true for a dispatched command")
+	}
+	if !em.Match(nil, "b") {
+		t.Error("expected Match to report true for a dispatched command")
+	}
+	em.Wait()
+	if code := em.ExitCode(); code != 0 {
+		t.Errorf("ExitCode() = %d, want 0", code)
+	}
+	if em.Err() != nil {
+		t.Errorf("Err() = %v, want nil", em.Err())
+	}
+}
+
+func TestExecMatcherExitCodeAndStartFailure(t *testing.T) {
+	em := WithExec([]string{"sh", "-c", "exit 3"})
+	em.Match(nil, "a")
+	em.Wait()
+	if code := em.ExitCode(); code != 3 {
+		t.Fatalf("ExitCode() = %d, want 3", code)
+	}
+
+	em2 := WithExec([]string{"does-not-exist-binary"})
+	em2.Match(nil, "a")
+	em2.Wait()
+	if code := em2.ExitCode(); code != -1 {
+		t.Errorf("ExitCode() = %d, want -1 for a failed-to-start command", code)
+	}
+	if em2.Err() == nil {
+		t.Error("expected Err() to report the failed-to-start error")
+	}
+}
+
+func TestExecBatchMatcherBatchesAndFlushesOnClose(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "batches.log")
+	// Each invocation appends one line per batch, so the log's line count
+	// tells us how many times runBatch actually ran a command.
+	ebm := WithExecBatch([]string{"sh", "-c", `echo "$@" >> ` + logFile, "_"}, 2)
+
+	ebm.Match(nil, "a")
+	ebm.Match(nil, "b")
+	ebm.Wait()
+	if lines := countLines(t, logFile); lines != 1 {
+		t.Fatalf("expected a full batch of 2 to run immediately, got %d batch(es)", lines)
+	}
+
+	ebm.Match(nil, "c")
+	ebm.Wait()
+	if lines := countLines(t, logFile); lines != 1 {
+		t.Fatalf("expected a partial batch to not run yet, got %d batch(es)", lines)
+	}
+
+	if err := ebm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if lines := countLines(t, logFile); lines != 2 {
+		t.Fatalf("expected Close to flush the partial batch, got %d batch(es)", lines)
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0
+		}
+		t.Fatal(err)
+	}
+	return len(strings.Split(strings.TrimRight(string(data), "\n"), "\n"))
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package finddd
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// fileid identifies a file independent of the path used to reach it, so
+// hardlinks and symlink targets can be recognized as the same file.
+type fileid struct {
+	dev uint64
+	ino uint64
+}
+
+func fileID(fsys fs.FS, path string) (fileid, bool) {
+	info, err := fs.Stat(fsys, path)
+	if err != nil {
+		return fileid{}, false
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileid{}, false
+	}
+	return fileid{dev: uint64(st.Dev), ino: uint64(st.Ino)}, true
+}
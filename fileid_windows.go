@@ -0,0 +1,36 @@
+//go:build windows
+
+package finddd
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// fileid identifies a file independent of the path used to reach it, so
+// hardlinks and symlink targets can be recognized as the same file.
+type fileid struct {
+	volume uint32
+	index  uint64
+}
+
+func fileID(fsys fs.FS, path string) (fileid, bool) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return fileid{}, false
+	}
+	defer f.Close()
+	osFile, ok := f.(*os.File)
+	if !ok {
+		return fileid{}, false
+	}
+	var data syscall.ByHandleFileInformation
+	if err := syscall.GetFileInformationByHandle(syscall.Handle(osFile.Fd()), &data); err != nil {
+		return fileid{}, false
+	}
+	return fileid{
+		volume: data.VolumeSerialNumber,
+		index:  uint64(data.FileIndexHigh)<<32 | uint64(data.FileIndexLow),
+	}, true
+}
@@ -0,0 +1,212 @@
+package finddd
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type FilenameMatchMode int
+
+const (
+	FMM_EXACT FilenameMatchMode = iota
+	FMM_STR
+	FMM_GLOB
+	FMM_RE
+	FMM_EXT
+	FMM_FUZZY
+)
+
+func WithPattern(pattern string) Option {
+	return func(m Matcher) {
+		fm, ok := m.(*FilenameMatcher)
+		if ok {
+			fm.rawPattern = pattern
+		}
+	}
+}
+
+func WithMode(mode FilenameMatchMode) Option {
+	return func(m Matcher) {
+		fm, ok := m.(*FilenameMatcher)
+		if ok {
+			fm.mode = mode
+		}
+	}
+}
+
+func WithIgnoreCase(ignoreCase bool) Option {
+	return func(m Matcher) {
+		fm, ok := m.(*FilenameMatcher)
+		if ok {
+			fm.ignoreCase = ignoreCase
+		}
+	}
+}
+
+// WithFullPath compares against the full path instead of just the
+// basename.
+func WithFullPath(fullPath bool) Option {
+	return func(m Matcher) {
+		fm, ok := m.(*FilenameMatcher)
+		if ok {
+			fm.fullPath = fullPath
+		}
+	}
+}
+
+// WithExts sets the extensions used by FMM_EXT.
+func WithExts(exts ...string) Option {
+	return func(m Matcher) {
+		fm, ok := m.(*FilenameMatcher)
+		if ok {
+			fm.exts = exts
+		}
+	}
+}
+
+// WithFuzzyThreshold sets the minimum normalized subsequence score (0..1)
+// required for FMM_FUZZY to match. Defaults to 0.6.
+func WithFuzzyThreshold(threshold float64) Option {
+	return func(m Matcher) {
+		fm, ok := m.(*FilenameMatcher)
+		if ok {
+			fm.fuzzyThreshold = threshold
+		}
+	}
+}
+
+// NewFilenameMatcher builds a FilenameMatcher. Unlike the other
+// constructors in this package it can fail, since FMM_RE compiles the
+// pattern up front.
+func NewFilenameMatcher(opts ...Option) (*FilenameMatcher, error) {
+	fm := &FilenameMatcher{mode: FMM_EXACT, fuzzyThreshold: 0.6}
+	for _, opt := range opts {
+		opt(fm)
+	}
+	switch {
+	case fm.mode == FMM_RE:
+		pattern := fm.rawPattern
+		if fm.ignoreCase {
+			// Fold case via the regexp's own (?i) flag instead of
+			// lowercasing the pattern text, which would silently
+			// destroy character classes like [A-Z].
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		fm.compiledPattern = re
+	case fm.ignoreCase:
+		fm.rawPattern = strings.ToLower(fm.rawPattern)
+	}
+	return fm, nil
+}
+
+type FilenameMatcher struct {
+	rawPattern      string
+	compiledPattern *regexp.Regexp
+	mode            FilenameMatchMode
+	ignoreCase      bool
+	fullPath        bool
+	exts            []string
+	fuzzyThreshold  float64
+}
+
+func (m *FilenameMatcher) Match(fsys fs.FS, path string) bool {
+	if m.mode == FMM_FUZZY {
+		name := strings.ToLower(filepath.Base(path))
+		score, ok := fuzzyScore(strings.ToLower(m.rawPattern), name)
+		return ok && score >= m.fuzzyThreshold
+	}
+
+	name := path
+	if !m.fullPath {
+		name = filepath.Base(path)
+	}
+	if m.ignoreCase && m.mode != FMM_RE {
+		name = strings.ToLower(name)
+	}
+
+	switch m.mode {
+	case FMM_EXACT:
+		return m.rawPattern == name
+	case FMM_STR:
+		return strings.Contains(name, m.rawPattern)
+	case FMM_GLOB:
+		ok, err := filepath.Match(m.rawPattern, name)
+		if err != nil {
+			return false
+		}
+		return ok
+	case FMM_RE:
+		return m.compiledPattern.MatchString(name)
+	case FMM_EXT:
+		return MatchExts(name, m.exts...)
+	}
+	return true
+}
+
+// MatchExt reports whether name's extension matches ext, ignoring case
+// and an optional leading dot on either side.
+func MatchExt(name, ext string) bool {
+	got := strings.TrimPrefix(extOf(name), ".")
+	return strings.EqualFold(got, strings.TrimPrefix(ext, "."))
+}
+
+// MatchExts reports whether name's extension matches any of exts.
+func MatchExts(name string, exts ...string) bool {
+	for _, ext := range exts {
+		if MatchExt(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyScore reports whether pattern is a subsequence of name and, if
+// so, an fzf-style score in [0, 1] that rewards consecutive runs and
+// penalizes loosely scattered matches.
+func fuzzyScore(pattern, name string) (score float64, ok bool) {
+	if pattern == "" {
+		return 1, true
+	}
+	pi, consecutive, total := 0, 0, 0.0
+	for ni := 0; ni < len(name) && pi < len(pattern); ni++ {
+		if name[ni] == pattern[pi] {
+			consecutive++
+			total += 1 + float64(consecutive)*0.5
+			pi++
+		} else {
+			consecutive = 0
+		}
+	}
+	if pi < len(pattern) {
+		return 0, false
+	}
+	maxPossible := float64(len(pattern))*1.5 + float64(len(name)-len(pattern))*0.1
+	if maxPossible <= 0 {
+		return 1, true
+	}
+	score = total / maxPossible
+	if score > 1 {
+		score = 1
+	}
+	return score, true
+}
+
+type notMatcher struct {
+	m Matcher
+}
+
+func (n *notMatcher) Match(fsys fs.FS, path string) bool {
+	return !n.m.Match(fsys, path)
+}
+
+// Not negates a Matcher, so rules that are naturally expressed as
+// exclusions don't each need their own inverted implementation.
+func Not(m Matcher) Matcher {
+	return &notMatcher{m: m}
+}
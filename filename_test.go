@@ -0,0 +1,95 @@
+package finddd
+
+import "testing"
+
+func TestFilenameMatcherModes(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []Option
+		path string
+		want bool
+	}{
+		{"exact", []Option{WithMode(FMM_EXACT), WithPattern("main.go")}, "src/main.go", true},
+		{"exact-miss", []Option{WithMode(FMM_EXACT), WithPattern("main.go")}, "src/other.go", false},
+		{"substring", []Option{WithMode(FMM_STR), WithPattern("ain")}, "main.go", true},
+		{"glob", []Option{WithMode(FMM_GLOB), WithPattern("*.go")}, "main.go", true},
+		{"glob-miss", []Option{WithMode(FMM_GLOB), WithPattern("*.go")}, "main.txt", false},
+		{"regex", []Option{WithMode(FMM_RE), WithPattern(`^main\.go$`)}, "main.go", true},
+		{"ext", []Option{WithMode(FMM_EXT), WithExts("go", "txt")}, "main.go", true},
+		{"ext-miss", []Option{WithMode(FMM_EXT), WithExts("txt")}, "main.go", false},
+		{"ignorecase", []Option{WithMode(FMM_EXACT), WithPattern("main.go"), WithIgnoreCase(true)}, "MAIN.GO", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fm, err := NewFilenameMatcher(c.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fm.Match(nil, c.path); got != c.want {
+				t.Errorf("Match(%q) = %v, want %v", c.path, got, c.want)
+			}
+		})
+	}
+}
+
+func TestFilenameMatcherFullPath(t *testing.T) {
+	fm, err := NewFilenameMatcher(WithMode(FMM_STR), WithPattern("src/"), WithFullPath(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fm.Match(nil, "src/main.go") {
+		t.Error("expected full-path match against src/main.go")
+	}
+	if fm.Match(nil, "other/main.go") {
+		t.Error("expected full-path match to fail against other/main.go")
+	}
+}
+
+func TestFilenameMatcherFuzzy(t *testing.T) {
+	fm, err := NewFilenameMatcher(WithMode(FMM_FUZZY), WithPattern("mgo"), WithFuzzyThreshold(0.1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fm.Match(nil, "main.go") {
+		t.Error("expected 'mgo' subsequence pattern... expected 'mgo' to fuzzy-match main.go")
+	}
+	if fm.Match(nil, "other.txt") {
+		t.Error("expected no fuzzy match when the pattern isn't a subsequence")
+	}
+}
+
+func TestFilenameMatcherFuzzyThreshold(t *testing.T) {
+	fm, err := NewFilenameMatcher(WithMode(FMM_FUZZY), WithPattern("mg"), WithFuzzyThreshold(0.99))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fm.Match(nil, "main.go") {
+		t.Error("expected a loosely scattered subsequence to fail a near-1.0 threshold")
+	}
+}
+
+func TestMatchExtsDotfile(t *testing.T) {
+	if MatchExt(".bashrc", "bashrc") {
+		t.Error("expected a dotfile with no further '.' to have no extension")
+	}
+	if !MatchExt("file.bashrc", "bashrc") {
+		t.Error("expected file.bashrc to match extension bashrc")
+	}
+	if !MatchExts("main.go", "txt", "go") {
+		t.Error("expected MatchExts to match any of its candidates")
+	}
+}
+
+func TestNot(t *testing.T) {
+	fm, err := NewFilenameMatcher(WithMode(FMM_GLOB), WithPattern("*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := Not(fm)
+	if n.Match(nil, "main.go") {
+		t.Error("expected Not to invert a match")
+	}
+	if !n.Match(nil, "main.txt") {
+		t.Error("expected Not to invert a non-match")
+	}
+}
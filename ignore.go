@@ -0,0 +1,305 @@
+package finddd
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+func WithIgnoreFiles(names ...string) Option {
+	return func(m Matcher) {
+		ifm, ok := m.(*IgnoreFileMatcher)
+		if ok {
+			ifm.names = names
+		}
+	}
+}
+
+func WithNoIgnoreVcs(noIgnoreVcs bool) Option {
+	return func(m Matcher) {
+		ifm, ok := m.(*IgnoreFileMatcher)
+		if ok {
+			ifm.noIgnoreVcs = noIgnoreVcs
+		}
+	}
+}
+
+func WithGlobalIgnoreFile(path string) Option {
+	return func(m Matcher) {
+		ifm, ok := m.(*IgnoreFileMatcher)
+		if ok {
+			ifm.globalFile = path
+		}
+	}
+}
+
+func NewIgnoreFileMatcher(opts ...Option) *IgnoreFileMatcher {
+	ifm := &IgnoreFileMatcher{
+		names:    []string{".gitignore", ".ignore", ".fdignore"},
+		dirRules: make(map[string][]ignoreRule),
+		ignored:  make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(ifm)
+	}
+	return ifm
+}
+
+// ignoreRule is a single compiled line from a .gitignore-style file.
+type ignoreRule struct {
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	re       *regexp.Regexp
+}
+
+// IgnoreFileMatcher honors .gitignore/.ignore/.fdignore files encountered
+// during the walk, inheriting rules from parent directories and letting
+// nested ignore files override them, the same way git and fd do.
+type IgnoreFileMatcher struct {
+	names       []string
+	noIgnoreVcs bool
+	globalFile  string
+
+	mu           sync.Mutex
+	dirRules     map[string][]ignoreRule
+	ignored      map[string]bool
+	globalRules  []ignoreRule
+	globalLoaded bool
+}
+
+func (m *IgnoreFileMatcher) Match(fsys fs.FS, path string) bool {
+	return !m.isIgnored(fsys, filepath.Clean(path))
+}
+
+// MatchPartial prunes a directory's whole subtree when it's ignored,
+// mirroring git's own behavior of never looking inside an excluded
+// directory for a deeper negation.
+func (m *IgnoreFileMatcher) MatchPartial(fsys fs.FS, dir string) bool {
+	return !m.isIgnored(fsys, filepath.Clean(dir))
+}
+
+func (m *IgnoreFileMatcher) isIgnored(fsys fs.FS, path string) bool {
+	if path == "." || path == "" {
+		return false
+	}
+	m.mu.Lock()
+	if v, ok := m.ignored[path]; ok {
+		m.mu.Unlock()
+		return v
+	}
+	m.mu.Unlock()
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		parent = "."
+	}
+	if m.isIgnored(fsys, parent) {
+		m.setIgnored(path, true)
+		return true
+	}
+
+	ignored := false
+	for _, r := range m.globalIgnoreRules() {
+		if m.ruleMatches(fsys, r, ".", path) {
+			ignored = !r.negate
+		}
+	}
+	for _, dir := range ancestorDirs(path) {
+		for _, r := range m.rulesFor(fsys, dir) {
+			if m.ruleMatches(fsys, r, dir, path) {
+				ignored = !r.negate
+			}
+		}
+	}
+	m.setIgnored(path, ignored)
+	return ignored
+}
+
+func (m *IgnoreFileMatcher) setIgnored(path string, ignored bool) {
+	m.mu.Lock()
+	m.ignored[path] = ignored
+	m.mu.Unlock()
+}
+
+func (m *IgnoreFileMatcher) ruleMatches(fsys fs.FS, r ignoreRule, dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	target := rel
+	if !r.anchored {
+		target = filepath.Base(rel)
+	}
+	if !r.re.MatchString(target) {
+		return false
+	}
+	if r.dirOnly {
+		info, err := fs.Stat(fsys, path)
+		if err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *IgnoreFileMatcher) rulesFor(fsys fs.FS, dir string) []ignoreRule {
+	m.mu.Lock()
+	if rules, ok := m.dirRules[dir]; ok {
+		m.mu.Unlock()
+		return rules
+	}
+	m.mu.Unlock()
+
+	var rules []ignoreRule
+	for _, name := range m.names {
+		if m.noIgnoreVcs && name == ".gitignore" {
+			continue
+		}
+		p := name
+		if dir != "." {
+			p = filepath.Join(dir, name)
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, parseIgnoreLines(data)...)
+	}
+
+	m.mu.Lock()
+	m.dirRules[dir] = rules
+	m.mu.Unlock()
+	return rules
+}
+
+func (m *IgnoreFileMatcher) globalIgnoreRules() []ignoreRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.globalFile == "" || m.globalLoaded {
+		return m.globalRules
+	}
+	m.globalLoaded = true
+	data, err := os.ReadFile(m.globalFile)
+	if err != nil {
+		return nil
+	}
+	m.globalRules = parseIgnoreLines(data)
+	return m.globalRules
+}
+
+// ancestorDirs returns the directories from the walk root ("." ) down to
+// and including path's parent, in the order rules should be applied.
+func ancestorDirs(path string) []string {
+	parent := filepath.Dir(path)
+	if parent == "." {
+		return []string{"."}
+	}
+	parts := strings.Split(filepath.ToSlash(parent), "/")
+	dirs := make([]string, 0, len(parts)+1)
+	dirs = append(dirs, ".")
+	cur := ""
+	for _, p := range parts {
+		if cur == "" {
+			cur = p
+		} else {
+			cur = cur + "/" + p
+		}
+		dirs = append(dirs, cur)
+	}
+	return dirs
+}
+
+func parseIgnoreLines(data []byte) []ignoreRule {
+	var rules []ignoreRule
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		negate := false
+		if strings.HasPrefix(line, "!") {
+			negate = true
+			line = line[1:]
+		}
+		if strings.HasPrefix(line, "\\") {
+			line = line[1:]
+		}
+		dirOnly := false
+		if strings.HasSuffix(line, "/") {
+			dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		anchored := false
+		if strings.HasPrefix(line, "/") {
+			anchored = true
+			line = strings.TrimPrefix(line, "/")
+		} else if strings.Contains(line, "/") {
+			anchored = true
+		}
+		re, err := regexp.Compile(translateGitignorePattern(line))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, ignoreRule{negate: negate, dirOnly: dirOnly, anchored: anchored, re: re})
+	}
+	return rules
+}
+
+// translateGitignorePattern converts a single gitignore glob (already split
+// into negate/dirOnly/anchored parts) into an anchored regexp, handling
+// "*", "?", "[...]" and "**" the way git does.
+func translateGitignorePattern(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		switch c := runes[i]; {
+		case c == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			if i+2 < len(runes) && runes[i+2] == '/' {
+				sb.WriteString("(?:.*/)?")
+				i += 3
+			} else {
+				sb.WriteString(".*")
+				i += 2
+			}
+		case c == '*':
+			sb.WriteString("[^/]*")
+			i++
+		case c == '?':
+			sb.WriteString("[^/]")
+			i++
+		case c == '[':
+			j := i + 1
+			for j < len(runes) && runes[j] != ']' {
+				j++
+			}
+			if j < len(runes) {
+				class := string(runes[i+1 : j])
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				sb.WriteString("[" + class + "]")
+				i = j + 1
+			} else {
+				sb.WriteString(`\[`)
+				i++
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+			i++
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
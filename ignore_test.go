@@ -0,0 +1,78 @@
+package finddd
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestIgnoreFileMatcherBasics(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":       &fstest.MapFile{Data: []byte("*.log\n/build/\n")},
+		"build/output.txt": &fstest.MapFile{},
+		"app.log":          &fstest.MapFile{},
+		"src/main.go":      &fstest.MapFile{},
+		"src/debug.log":    &fstest.MapFile{},
+	}
+	m := NewIgnoreFileMatcher()
+
+	cases := map[string]bool{
+		"app.log":          false,
+		"build/output.txt": false,
+		"src/main.go":      true,
+		"src/debug.log":    false,
+	}
+	for path, want := range cases {
+		if got := m.Match(fsys, path); got != want {
+			t.Errorf("Match(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestIgnoreFileMatcherNestedOverride(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":         &fstest.MapFile{Data: []byte("*.log\n")},
+		"keep/.gitignore":    &fstest.MapFile{Data: []byte("!important.log\n")},
+		"keep/important.log": &fstest.MapFile{},
+		"keep/other.log":     &fstest.MapFile{},
+	}
+	m := NewIgnoreFileMatcher()
+	if !m.Match(fsys, "keep/important.log") {
+		t.Error("expected keep/important.log to be re-included by the nested negation")
+	}
+	if m.Match(fsys, "keep/other.log") {
+		t.Error("expected keep/other.log to remain ignored by the parent rule")
+	}
+}
+
+func TestIgnoreFileMatcherGlobstar(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":     &fstest.MapFile{Data: []byte("**/*.tmp\n")},
+		"a/b/c/file.tmp": &fstest.MapFile{},
+		"a/b/c/file.txt": &fstest.MapFile{},
+	}
+	m := NewIgnoreFileMatcher()
+	if m.Match(fsys, "a/b/c/file.tmp") {
+		t.Error("expected **/*.tmp to ignore a deeply nested file.tmp")
+	}
+	if !m.Match(fsys, "a/b/c/file.txt") {
+		t.Error("expected file.txt to be kept")
+	}
+}
+
+func TestIgnoreFileMatcherPartialPrunesWholeDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore":              &fstest.MapFile{Data: []byte("node_modules/\n")},
+		"node_modules/a/pkg.json": &fstest.MapFile{},
+		"src/app.js":              &fstest.MapFile{},
+	}
+	m := NewIgnoreFileMatcher()
+	if m.Match(fsys, "node_modules/a/pkg.json") {
+		t.Error("expected a file under an ignored directory to be ignored")
+	}
+	if m.MatchPartial(fsys, "node_modules") {
+		t.Error("expected MatchPartial to prune the ignored directory's subtree")
+	}
+	if !m.MatchPartial(fsys, "src") {
+		t.Error("expected MatchPartial to keep descending into a non-ignored directory")
+	}
+}
@@ -1,10 +1,11 @@
 package finddd
 
 import (
+	"context"
 	"io/fs"
 	"path/filepath"
-	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -34,6 +35,19 @@ func (m *MultiMatcher) Match(fsys fs.FS, path string) bool {
 	}
 	return true
 }
+
+// MatchPartial aggregates the PartialMatcher verdicts of the sub-matchers
+// that implement it; a directory is worth descending into unless one of
+// them rules it out entirely.
+func (m *MultiMatcher) MatchPartial(fsys fs.FS, path string) bool {
+	for _, v := range m.ms {
+		pm, ok := v.(PartialMatcher)
+		if ok && !pm.MatchPartial(fsys, path) {
+			return false
+		}
+	}
+	return true
+}
 func WithSuffixes(suffixes ...string) Option {
 	return func(m Matcher) {
 		sm, ok := m.(*SuffixMatcher)
@@ -276,12 +290,6 @@ func (m *HiddenMatcher) Match(fsys fs.FS, path string) bool {
 	return strings.HasPrefix(filepath.Base(path), ".")
 }
 
-type IgnoreFileMatcher struct{}
-
-func (m *IgnoreFileMatcher) Match(fsys fs.FS, path string) bool {
-	return true
-}
-
 func WithMaxDepth(max int) Option {
 	return func(m Matcher) {
 		dm, ok := m.(*DepthMatcher)
@@ -349,67 +357,45 @@ func WithMaxResult(max int) Option {
 }
 
 func NewMaxResultMatcher(opts ...Option) *MaxResultMatcher {
-	mrm := &MaxResultMatcher{max: -1}
+	ctx, cancel := context.WithCancel(context.Background())
+	mrm := &MaxResultMatcher{max: -1, ctx: ctx, cancel: cancel}
 	for _, opt := range opts {
 		opt(mrm)
 	}
 	return mrm
 }
 
+// MaxResultMatcher stops accepting matches once max results have been
+// produced. The counter is atomic and, once max is reached, the matcher
+// cancels its context so a concurrent walker can stop early instead of
+// just having Match start returning false.
 type MaxResultMatcher struct {
-	count int
-	max   int
+	count  int64
+	max    int
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 func (m *MaxResultMatcher) Match(fsys fs.FS, path string) bool {
 	if m.max < 0 {
 		return true
 	}
-	ok := m.count < m.max
-	if ok {
-		m.count += 1
+	n := atomic.AddInt64(&m.count, 1)
+	if n > int64(m.max) {
+		m.cancel()
+		return false
+	}
+	if n == int64(m.max) {
+		m.cancel()
 	}
-	return ok
+	return true
 }
 
-type FilenameMatchMode int
-
-const (
-	FMM_EXACT FilenameMatchMode = iota
-	FMM_STR
-	FMM_GLOB
-	FMM_RE
-)
-
-type FilenameMatcher struct {
-	rawPattern      string
-	compiledPattern *regexp.Regexp
-	mode            FilenameMatchMode
-	ignoreCase      bool
-}
-
-func (m *FilenameMatcher) Match(fsys fs.FS, path string) bool {
-	panic("todo")
-	m.compiledPattern = regexp.MustCompile("foo")
-	name := filepath.Base(path)
-	if m.ignoreCase {
-		name = strings.ToLower(name)
-	}
-	switch m.mode {
-	case FMM_EXACT:
-		return m.rawPattern == name
-	case FMM_STR:
-		return strings.Contains(name, m.rawPattern)
-	case FMM_GLOB:
-		ok, err := filepath.Match(m.rawPattern, name)
-		if err != nil {
-			return false
-		}
-		return ok
-	case FMM_RE:
-		return len(m.compiledPattern.FindStringSubmatch(name)) > 0
-	}
-	return true
+// Done implements Canceler so a walker can stop dispatching work as soon
+// as the result limit is hit, rather than relying on every in-flight
+// Match call to observe the count.
+func (m *MaxResultMatcher) Done() <-chan struct{} {
+	return m.ctx.Done()
 }
 
 func assert(ok bool, msg any) {
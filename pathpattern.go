@@ -0,0 +1,109 @@
+package finddd
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PartialMatcher is implemented by matchers that can tell the walker a
+// directory doesn't match yet but might still contain matches further
+// down, so it is worth descending into rather than pruning outright.
+type PartialMatcher interface {
+	MatchPartial(fsys fs.FS, dir string) bool
+}
+
+func WithIncludePatterns(patterns ...string) Option {
+	return func(m Matcher) {
+		ppm, ok := m.(*PathPatternMatcher)
+		if ok {
+			ppm.include = patterns
+		}
+	}
+}
+
+func WithExcludePatterns(patterns ...string) Option {
+	return func(m Matcher) {
+		ppm, ok := m.(*PathPatternMatcher)
+		if ok {
+			ppm.exclude = patterns
+		}
+	}
+}
+
+func NewPathPatternMatcher(opts ...Option) *PathPatternMatcher {
+	ppm := &PathPatternMatcher{}
+	for _, opt := range opts {
+		opt(ppm)
+	}
+	return ppm
+}
+
+// PathPatternMatcher matches include/exclude glob patterns against the
+// path relative to the walk root, rather than just filepath.Base(path)
+// like FilenameMatcher and SuffixMatcher do. Patterns with more path
+// components than the current path are treated as "partial" matches via
+// MatchPartial, which tells the walker to keep descending instead of
+// pruning the subtree.
+type PathPatternMatcher struct {
+	include []string
+	exclude []string
+}
+
+func (m *PathPatternMatcher) Match(fsys fs.FS, p string) bool {
+	rel := filepath.ToSlash(p)
+	if len(m.include) > 0 {
+		matched := false
+		for _, pattern := range m.include {
+			if ok, _ := matchPattern(pattern, rel); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, pattern := range m.exclude {
+		if ok, _ := matchPattern(pattern, rel); ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *PathPatternMatcher) MatchPartial(fsys fs.FS, dir string) bool {
+	rel := filepath.ToSlash(dir)
+	for _, pattern := range m.exclude {
+		if matched, _ := matchPattern(pattern, rel); matched {
+			return false
+		}
+	}
+	if len(m.include) == 0 {
+		return true
+	}
+	for _, pattern := range m.include {
+		matched, partial := matchPattern(pattern, rel)
+		if matched || partial {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern reports whether pattern fully matches name, and separately
+// whether name is a prefix of pattern's directory components (partial),
+// meaning a directory that doesn't match yet might still contain matches.
+func matchPattern(pattern, name string) (matched, partial bool) {
+	nameSeps := strings.Count(name, "/")
+	patSeps := strings.Count(pattern, "/")
+	if patSeps > nameSeps {
+		parts := strings.SplitN(pattern, "/", nameSeps+2)
+		prefix := strings.Join(parts[:nameSeps+1], "/")
+		ok, _ := path.Match(prefix, name)
+		return false, ok
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok, false
+}
@@ -0,0 +1,49 @@
+package finddd
+
+import "testing"
+
+func TestMatchPatternPartialVsFull(t *testing.T) {
+	matched, partial := matchPattern("foo/bar/*", "foo")
+	if matched {
+		t.Error("expected no full match before the path reaches the pattern's depth")
+	}
+	if !partial {
+		t.Error("expected a partial match so the walker keeps descending")
+	}
+
+	matched, partial = matchPattern("foo/bar/*", "foo/bar/baz.txt")
+	if !matched {
+		t.Error("expected a full match once the path reaches the pattern's depth")
+	}
+	if partial {
+		t.Error("partial should be false once matched is true")
+	}
+
+	matched, partial = matchPattern("foo/bar/*", "qux")
+	if matched || partial {
+		t.Error("expected neither match nor partial for an unrelated prefix")
+	}
+}
+
+func TestPathPatternMatcherIncludeExclude(t *testing.T) {
+	m := NewPathPatternMatcher(
+		WithIncludePatterns("src/*.go"),
+		WithExcludePatterns("src/*_test.go"),
+	)
+	if !m.Match(nil, "src/main.go") {
+		t.Error("expected src/main.go to match the include pattern")
+	}
+	if m.Match(nil, "src/main_test.go") {
+		t.Error("expected src/main_test.go to be excluded")
+	}
+	if m.Match(nil, "other/main.go") {
+		t.Error("expected other/main.go to not match the include pattern")
+	}
+
+	if !m.MatchPartial(nil, "src") {
+		t.Error("expected MatchPartial to keep descending into src")
+	}
+	if m.MatchPartial(nil, "other") {
+		t.Error("expected MatchPartial to prune a directory with no possible match below it")
+	}
+}
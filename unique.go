@@ -0,0 +1,33 @@
+package finddd
+
+import (
+	"io/fs"
+	"sync"
+)
+
+func NewUniqueMatcher() *UniqueMatcher {
+	return &UniqueMatcher{seen: make(map[fileid]struct{})}
+}
+
+// UniqueMatcher dedupes results by (dev, ino) (or the Windows file index
+// equivalent), so hardlinked duplicates encountered via different paths
+// are only emitted once. Files on a filesystem that can't report an id
+// are always kept.
+type UniqueMatcher struct {
+	mu   sync.Mutex
+	seen map[fileid]struct{}
+}
+
+func (m *UniqueMatcher) Match(fsys fs.FS, path string) bool {
+	id, ok := fileID(fsys, path)
+	if !ok {
+		return true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, dup := m.seen[id]; dup {
+		return false
+	}
+	m.seen[id] = struct{}{}
+	return true
+}
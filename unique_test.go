@@ -0,0 +1,35 @@
+package finddd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUniqueMatcherDedupesHardlinks(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "original.txt")
+	if err := os.WriteFile(original, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	linked := filepath.Join(dir, "linked.txt")
+	if err := os.Link(original, linked); err != nil {
+		t.Skipf("hardlinks not supported here: %v", err)
+	}
+	other := filepath.Join(dir, "other.txt")
+	if err := os.WriteFile(other, []byte("data2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := os.DirFS(dir)
+	m := NewUniqueMatcher()
+	if !m.Match(fsys, "original.txt") {
+		t.Error("expected the first path to a file to match")
+	}
+	if m.Match(fsys, "linked.txt") {
+		t.Error("expected a hardlinked duplicate to be deduped")
+	}
+	if !m.Match(fsys, "other.txt") {
+		t.Error("expected an unrelated file to still match")
+	}
+}
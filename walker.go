@@ -0,0 +1,230 @@
+package finddd
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+)
+
+// Canceler is implemented by matchers (such as MaxResultMatcher) that can
+// signal the walker to stop dispatching new work early.
+type Canceler interface {
+	Done() <-chan struct{}
+}
+
+const (
+	defaultWorkers   = 4
+	throttleBatch    = 32
+	throttleBaseWait = 5 * time.Millisecond
+)
+
+type WalkOption func(*Walker)
+
+// WithWorkers sets the size of the worker pool that runs the Matcher
+// chain against each candidate. Defaults to 4.
+func WithWorkers(n int) WalkOption {
+	return func(w *Walker) {
+		if n > 0 {
+			w.workers = n
+		}
+	}
+}
+
+// WithThrottle controls how much the producer backs off between batches
+// of directory reads: 0.0 always sleeps between batches, 1.0 (the
+// default) never does.
+func WithThrottle(f float64) WalkOption {
+	return func(w *Walker) {
+		if f < 0 {
+			f = 0
+		}
+		if f > 1 {
+			f = 1
+		}
+		w.throttle = f
+	}
+}
+
+// WithFollowSymlinks makes the walker descend into symlinked
+// directories instead of only reporting them as leaves.
+func WithFollowSymlinks(follow bool) WalkOption {
+	return func(w *Walker) {
+		w.followSymlinks = follow
+	}
+}
+
+func NewWalker(opts ...WalkOption) *Walker {
+	w := &Walker{workers: defaultWorkers, throttle: 1}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Walker drives a Matcher chain over an fs.FS as a producer/consumer
+// pipeline: one goroutine walks directories and pushes candidates onto a
+// buffered channel, while a pool of workers runs the Matcher against
+// each candidate and forwards the ones that match.
+type Walker struct {
+	workers        int
+	throttle       float64
+	followSymlinks bool
+}
+
+type walkCandidate struct {
+	path  string
+	isDir bool
+}
+
+// Walk starts the pipeline and returns a channel of matching paths and a
+// channel that carries at most one error from the producer. Both are
+// closed once the walk completes, the context is canceled, or a
+// Canceler matcher signals it's done.
+func (w *Walker) Walk(ctx context.Context, fsys fs.FS, root string, m Matcher) (<-chan string, <-chan error) {
+	results := make(chan string)
+	errs := make(chan error, 1)
+	candidates := make(chan walkCandidate, w.workers*4)
+
+	ctx, cancel := context.WithCancel(ctx)
+	if c, ok := findCanceler(m); ok {
+		go func() {
+			select {
+			case <-c.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	var workerWg sync.WaitGroup
+	workerWg.Add(w.workers)
+	for i := 0; i < w.workers; i++ {
+		go func() {
+			defer workerWg.Done()
+			for cand := range candidates {
+				if !m.Match(fsys, cand.path) {
+					continue
+				}
+				select {
+				case results <- cand.path:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(candidates)
+		visited := make(map[fileid]bool)
+		if err := w.produce(ctx, fsys, root, m, candidates, visited); err != nil && ctx.Err() == nil {
+			select {
+			case errs <- err:
+			default:
+			}
+		}
+	}()
+
+	go func() {
+		workerWg.Wait()
+		close(results)
+		close(errs)
+		cancel()
+	}()
+
+	return results, errs
+}
+
+func (w *Walker) produce(ctx context.Context, fsys fs.FS, dir string, m Matcher, out chan<- walkCandidate, visited map[fileid]bool) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if w.followSymlinks {
+		if id, ok := fileID(fsys, dir); ok {
+			visited[id] = true
+		}
+	}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	pm, hasPartial := m.(PartialMatcher)
+	for i, de := range entries {
+		childPath := de.Name()
+		if dir != "." {
+			childPath = path.Join(dir, de.Name())
+		}
+
+		isDir := de.IsDir()
+		descend := isDir
+		if de.Type()&fs.ModeSymlink != 0 {
+			isDir, descend = w.resolveSymlink(fsys, childPath, visited)
+		}
+
+		if err := w.emit(ctx, out, walkCandidate{path: childPath, isDir: isDir}); err != nil {
+			return err
+		}
+		if descend && (!hasPartial || pm.MatchPartial(fsys, childPath)) {
+			if err := w.produce(ctx, fsys, childPath, m, out, visited); err != nil {
+				return err
+			}
+		}
+
+		if w.throttle < 1 && i%throttleBatch == throttleBatch-1 {
+			time.Sleep(time.Duration(float64(throttleBaseWait) * (1 - w.throttle)))
+		}
+	}
+	return nil
+}
+
+// resolveSymlink reports whether a symlink entry points at a directory
+// and, when WithFollowSymlinks is set, whether the walker should descend
+// into it. It refuses to revisit a directory it has already walked, so
+// a cyclic link farm terminates instead of recursing forever.
+func (w *Walker) resolveSymlink(fsys fs.FS, childPath string, visited map[fileid]bool) (isDir, descend bool) {
+	info, err := fs.Stat(fsys, childPath)
+	if err != nil || !info.IsDir() {
+		return false, false
+	}
+	if !w.followSymlinks {
+		return true, false
+	}
+	id, ok := fileID(fsys, childPath)
+	if ok {
+		if visited[id] {
+			return true, false
+		}
+		visited[id] = true
+	}
+	return true, true
+}
+
+func (w *Walker) emit(ctx context.Context, out chan<- walkCandidate, cand walkCandidate) error {
+	select {
+	case out <- cand:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func findCanceler(m Matcher) (Canceler, bool) {
+	if c, ok := m.(Canceler); ok {
+		return c, true
+	}
+	if mm, ok := m.(*MultiMatcher); ok {
+		for _, sub := range mm.ms {
+			if c, ok := sub.(Canceler); ok {
+				return c, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,49 @@
+package finddd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestWalkerTerminatesOnSymlinkCycle proves WithFollowSymlinks(true)
+// doesn't recurse forever into a self-referential symlink.
+func TestWalkerTerminatesOnSymlinkCycle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "loop"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "loop"), filepath.Join(dir, "loop", "self")); err != nil {
+		t.Skipf("symlinks not supported here: %v", err)
+	}
+
+	fsys := os.DirFS(dir)
+	w := NewWalker(WithWorkers(2), WithFollowSymlinks(true))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results, errs := w.Walk(ctx, fsys, ".", &MultiMatcher{})
+
+	var got []string
+	for p := range results {
+		got = append(got, p)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("walk did not terminate before the timeout")
+	}
+	// loop/self points back at loop, which has already been walked, so it
+	// is reported but not descended into: loop, loop/self.
+	want := map[string]bool{"loop": true, "loop/self": true}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want (unordered) %v", got, want)
+	}
+	for _, p := range got {
+		if !want[p] {
+			t.Errorf("unexpected path in results: %s", p)
+		}
+	}
+}
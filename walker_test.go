@@ -0,0 +1,57 @@
+package finddd
+
+import (
+	"context"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// countingFS wraps an fs.FS to count ReadDir calls, so pruning behavior
+// can be verified by I/O volume rather than just by the final results.
+type countingFS struct {
+	fs.FS
+	readDirCalls *int
+}
+
+func (c countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	*c.readDirCalls++
+	return fs.ReadDir(c.FS, name)
+}
+
+func TestWalkerPrunesIgnoredSubtree(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitignore": &fstest.MapFile{Data: []byte("node_modules/\n")},
+		"node_modules/a/b/c/d/e/f/g/h/deep.js": &fstest.MapFile{},
+		"src/app.js":                           &fstest.MapFile{},
+	}
+	var calls int
+	cfs := countingFS{FS: fsys, readDirCalls: &calls}
+
+	mm := &MultiMatcher{}
+	mm.Add(NewIgnoreFileMatcher())
+
+	w := NewWalker(WithWorkers(2))
+	results, errs := w.Walk(context.Background(), cfs, ".", mm)
+
+	var got []string
+	for p := range results {
+		got = append(got, p)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range got {
+		if p == "node_modules" || strings.HasPrefix(p, "node_modules/") {
+			t.Errorf("unexpected ignored path in results: %s", p)
+		}
+	}
+
+	// Only "." and "src" should ever be read; the eight nested levels
+	// under the ignored node_modules/ must never be visited.
+	if calls > 2 {
+		t.Errorf("expected the ignored subtree to be pruned without recursing into it, got %d ReadDir calls", calls)
+	}
+}